@@ -0,0 +1,78 @@
+package gowebdav
+
+import (
+	"io"
+	"sync"
+)
+
+// defaultCopyBufferSize is used by Client.copyBuffer when no pool has been
+// configured via SetBufferPool, and as the buffer size of the default pool
+// that SetBufferPool(nil) restores.
+const defaultCopyBufferSize = 32 * 1024
+
+// BufferPool is the interface used by Client to obtain scratch buffers for
+// streaming copies (Read, Write, WriteStream and the multipart range
+// readers). It is satisfied by *sync.Pool holding []byte values. Sharing a
+// single pool across many Client instances avoids a fresh allocation per
+// transfer on high-throughput mounts.
+type BufferPool interface {
+	Get() []byte
+	Put([]byte)
+}
+
+// syncBufferPool adapts a sync.Pool of fixed-size []byte buffers to the
+// BufferPool interface.
+type syncBufferPool struct {
+	size int
+	pool sync.Pool
+}
+
+func newSyncBufferPool(size int) *syncBufferPool {
+	p := &syncBufferPool{size: size}
+	p.pool.New = func() any {
+		return make([]byte, p.size)
+	}
+	return p
+}
+
+func (p *syncBufferPool) Get() []byte {
+	return p.pool.Get().([]byte)
+}
+
+func (p *syncBufferPool) Put(buf []byte) {
+	p.pool.Put(buf) //nolint:staticcheck // buf is reused as-is, length is fixed by newSyncBufferPool
+}
+
+// SetBufferPool configures the BufferPool used for internal io.CopyBuffer
+// calls. Passing nil restores the Client's own default pool.
+func (c *Client) SetBufferPool(pool BufferPool) {
+	if pool == nil {
+		pool = newSyncBufferPool(defaultCopyBufferSize)
+	}
+	c.bufferPool = pool
+}
+
+// SetCopyBufferSize sets the size of the buffers used by the Client's default
+// BufferPool. It has no effect after SetBufferPool has been called with a
+// custom pool. size must be positive; non-positive values are ignored.
+func (c *Client) SetCopyBufferSize(size int) {
+	if size <= 0 {
+		return
+	}
+	if _, isDefault := c.bufferPool.(*syncBufferPool); c.bufferPool != nil && !isDefault {
+		return
+	}
+	c.bufferPool = newSyncBufferPool(size)
+}
+
+// copyBuffer copies from src to dst using a buffer borrowed from the
+// Client's BufferPool, falling back to a default pool if none was set.
+func (c *Client) copyBuffer(dst io.Writer, src io.Reader) (int64, error) {
+	pool := c.bufferPool
+	if pool == nil {
+		pool = newSyncBufferPool(defaultCopyBufferSize)
+	}
+	buf := pool.Get()
+	defer pool.Put(buf)
+	return io.CopyBuffer(dst, src, buf)
+}
@@ -0,0 +1,72 @@
+package gowebdav
+
+import (
+	"bytes"
+	"sync/atomic"
+	"testing"
+)
+
+type countingPool struct {
+	size int
+	gets int32
+}
+
+func (p *countingPool) Get() []byte {
+	atomic.AddInt32(&p.gets, 1)
+	return make([]byte, p.size)
+}
+
+func (p *countingPool) Put([]byte) {}
+
+func TestSetBufferPool(t *testing.T) {
+	cli, srv, _, ctx := newServer(t)
+	defer srv.Close()
+
+	pool := &countingPool{size: 4}
+	cli.SetBufferPool(pool)
+
+	stream, err := cli.ReadStream(ctx, "/hello.txt")
+	if err != nil {
+		t.Fatalf("got: %v, want stream", err)
+	}
+	buf := new(bytes.Buffer)
+	if _, err := cli.copyBuffer(buf, stream); err != nil {
+		t.Fatalf("got: %v, want nil", err)
+	}
+	if buf.String() != "hello gowebdav\n" {
+		t.Fatalf("got: %q, want: %q", buf.String(), "hello gowebdav\n")
+	}
+	if atomic.LoadInt32(&pool.gets) == 0 {
+		t.Fatalf("want custom pool to be used at least once")
+	}
+}
+
+func TestWriteStreamUsesBufferPool(t *testing.T) {
+	cli, srv, _, ctx := newServer(t)
+	defer srv.Close()
+
+	pool := &countingPool{size: 4}
+	cli.SetBufferPool(pool)
+
+	if err := cli.Write(ctx, "/pooled.txt", []byte("hello gowebdav\n"), 0644); err != nil {
+		t.Fatalf("got: %v, want nil", err)
+	}
+	if atomic.LoadInt32(&pool.gets) == 0 {
+		t.Fatalf("want custom pool to be used by Write/WriteStream at least once")
+	}
+}
+
+func TestSetCopyBufferSize(t *testing.T) {
+	cli, srv, _, _ := newServer(t)
+	defer srv.Close()
+
+	cli.SetCopyBufferSize(8)
+	src := bytes.NewBufferString("hello gowebdav\n")
+	dst := new(bytes.Buffer)
+	if _, err := cli.copyBuffer(dst, src); err != nil {
+		t.Fatalf("got: %v, want nil", err)
+	}
+	if dst.String() != "hello gowebdav\n" {
+		t.Fatalf("got: %q, want: %q", dst.String(), "hello gowebdav\n")
+	}
+}
@@ -0,0 +1,248 @@
+package gowebdav
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"os"
+)
+
+func overwriteHeader(overwrite bool) string {
+	if overwrite {
+		return "T"
+	}
+	return "F"
+}
+
+// ReadDir lists the contents of path, which must be a collection.
+func (c *Client) ReadDir(ctx context.Context, path string) ([]os.FileInfo, error) {
+	responses, err := c.getProps(ctx, path, "1")
+	if err != nil {
+		return nil, err
+	}
+
+	self := normalizeHref(path)
+	var files []os.FileInfo
+	for _, r := range responses {
+		if normalizeHref(r.Href) == self {
+			continue
+		}
+		for _, p := range r.Props {
+			files = append(files, toFile(r.Href, p))
+			break
+		}
+	}
+	return files, nil
+}
+
+// Stat returns the os.FileInfo for path.
+func (c *Client) Stat(ctx context.Context, path string) (os.FileInfo, error) {
+	responses, err := c.getProps(ctx, path, "0")
+	if err != nil {
+		return nil, err
+	}
+	if len(responses) == 0 || len(responses[0].Props) == 0 {
+		return nil, newPathError("Stat", path, http.StatusNotFound)
+	}
+	return toFile(responses[0].Href, responses[0].Props[0]), nil
+}
+
+// Read returns the full contents of path.
+func (c *Client) Read(ctx context.Context, path string) ([]byte, error) {
+	stream, err := c.ReadStream(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+
+	buf := new(bytes.Buffer)
+	if _, err := c.copyBuffer(buf, stream); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ReadStream returns a reader over the full contents of path. The caller
+// must Close it. Unlike Read and WriteStream, ReadStream performs no
+// internal copy (there is nothing to buffer: the response body is handed
+// straight to the caller), so the BufferPool configured via SetBufferPool
+// has nothing to do here; it governs the copies Read, WriteStream and the
+// multipart range readers make into and out of that body.
+func (c *Client) ReadStream(ctx context.Context, path string) (io.ReadCloser, error) {
+	rs, err := c.req(ctx, "GET", path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if rs.StatusCode < 200 || rs.StatusCode >= 300 {
+		rs.Body.Close()
+		return nil, newPathError("ReadStream", path, rs.StatusCode)
+	}
+	return rs.Body, nil
+}
+
+// ReadStreamRange returns a reader over length bytes of path starting at
+// offset. length of 0 means "to the end of the resource".
+func (c *Client) ReadStreamRange(ctx context.Context, path string, offset, length int64) (io.ReadCloser, error) {
+	rs, err := c.req(ctx, "GET", path, nil, func(r *http.Request) {
+		r.Header.Set("Range", rangeHeader([]Range{{Start: offset, Length: length}}))
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rs.StatusCode != http.StatusPartialContent && rs.StatusCode != http.StatusOK {
+		rs.Body.Close()
+		return nil, newPathError("ReadStreamRange", path, rs.StatusCode)
+	}
+	return rs.Body, nil
+}
+
+// Mkdir creates the collection at path. It is a no-op, not an error, if the
+// collection already exists.
+func (c *Client) Mkdir(ctx context.Context, path string, _ os.FileMode) error {
+	rs, err := c.req(ctx, "MKCOL", path, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer rs.Body.Close()
+
+	if rs.StatusCode == http.StatusMethodNotAllowed {
+		return nil
+	}
+	if rs.StatusCode != http.StatusCreated {
+		return newPathError("Mkdir", path, rs.StatusCode)
+	}
+	return nil
+}
+
+// MkdirAll creates path and any missing parents, like os.MkdirAll.
+func (c *Client) MkdirAll(ctx context.Context, path string, mode os.FileMode) error {
+	segments := splitPath(path)
+	cur := ""
+	for _, s := range segments {
+		cur += "/" + s
+		if err := c.Mkdir(ctx, cur, mode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func splitPath(path string) []string {
+	var segments []string
+	start := 0
+	for i := 0; i <= len(path); i++ {
+		if i == len(path) || path[i] == '/' {
+			if i > start {
+				segments = append(segments, path[start:i])
+			}
+			start = i + 1
+		}
+	}
+	return segments
+}
+
+// Copy copies oldpath to newpath. If overwrite is false, Copy fails if
+// newpath already exists.
+func (c *Client) Copy(ctx context.Context, oldpath, newpath string, overwrite bool) error {
+	rs, err := c.req(ctx, "COPY", oldpath, nil, func(r *http.Request) {
+		r.Header.Set("Destination", c.abs(newpath))
+		r.Header.Set("Overwrite", overwriteHeader(overwrite))
+	})
+	if err != nil {
+		return err
+	}
+	defer rs.Body.Close()
+
+	if rs.StatusCode < 200 || rs.StatusCode >= 300 {
+		return newPathError("Copy", oldpath, rs.StatusCode)
+	}
+	return nil
+}
+
+// Rename moves oldpath to newpath. If overwrite is false, Rename fails if
+// newpath already exists.
+func (c *Client) Rename(ctx context.Context, oldpath, newpath string, overwrite bool) error {
+	rs, err := c.req(ctx, "MOVE", oldpath, nil, func(r *http.Request) {
+		r.Header.Set("Destination", c.abs(newpath))
+		r.Header.Set("Overwrite", overwriteHeader(overwrite))
+	})
+	if err != nil {
+		return err
+	}
+	defer rs.Body.Close()
+
+	if rs.StatusCode < 200 || rs.StatusCode >= 300 {
+		return newPathError("Rename", oldpath, rs.StatusCode)
+	}
+	return nil
+}
+
+// Remove deletes path. It is not an error if path doesn't exist.
+func (c *Client) Remove(ctx context.Context, path string) error {
+	rs, err := c.req(ctx, "DELETE", path, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer rs.Body.Close()
+
+	if rs.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if rs.StatusCode < 200 || rs.StatusCode >= 300 {
+		return newPathError("Remove", path, rs.StatusCode)
+	}
+	return nil
+}
+
+// RemoveAll deletes path and, if it is a collection, everything under it.
+// It is not an error if path doesn't exist. WebDAV DELETE is recursive for
+// collections, so this is equivalent to Remove.
+func (c *Client) RemoveAll(ctx context.Context, path string) error {
+	return c.Remove(ctx, path)
+}
+
+// Write replaces the contents of path with data, creating it (and any
+// missing parent collections) if it doesn't exist.
+func (c *Client) Write(ctx context.Context, path string, data []byte, mode os.FileMode) error {
+	return c.WriteStream(ctx, path, bytes.NewReader(data), mode)
+}
+
+// WriteStream replaces the contents of path with stream, creating it (and
+// any missing parent collections) if it doesn't exist.
+func (c *Client) WriteStream(ctx context.Context, path string, stream io.Reader, mode os.FileMode) error {
+	buf := new(bytes.Buffer)
+	if _, err := c.copyBuffer(buf, stream); err != nil {
+		return err
+	}
+	data := buf.Bytes()
+
+	status, err := c.putFile(ctx, path, data)
+	if err != nil {
+		return err
+	}
+
+	if status == http.StatusConflict {
+		if err := c.MkdirAll(ctx, parentDir(path), mode); err != nil {
+			return err
+		}
+		status, err = c.putFile(ctx, path, data)
+		if err != nil {
+			return err
+		}
+	}
+
+	if status < 200 || status >= 300 {
+		return newPathError("WriteStream", path, status)
+	}
+	return nil
+}
+
+func (c *Client) putFile(ctx context.Context, path string, data []byte) (int, error) {
+	rs, err := c.req(ctx, "PUT", path, bytes.NewReader(data), nil)
+	if err != nil {
+		return 0, err
+	}
+	defer rs.Body.Close()
+	return rs.StatusCode, nil
+}
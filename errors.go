@@ -0,0 +1,40 @@
+package gowebdav
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// StatusError wraps an unexpected HTTP status code returned by the server.
+// It is normally found inside an *os.PathError's Err field; use IsErrCode
+// or IsErrNotFound to test for it without unwrapping by hand.
+type StatusError struct {
+	Status int
+}
+
+func (se StatusError) Error() string {
+	return fmt.Sprintf("%d %s", se.Status, http.StatusText(se.Status))
+}
+
+// newPathError wraps code as a StatusError inside an os.PathError carrying
+// op and path, matching the shape every Client method returns on an
+// unexpected response.
+func newPathError(op, path string, code int) error {
+	return &os.PathError{Op: op, Path: path, Err: StatusError{Status: code}}
+}
+
+// IsErrCode reports whether err is a *os.PathError wrapping a StatusError
+// with the given HTTP status code.
+func IsErrCode(err error, code int) bool {
+	if pe, ok := err.(*os.PathError); ok {
+		se, ok := pe.Err.(StatusError)
+		return ok && se.Status == code
+	}
+	return false
+}
+
+// IsErrNotFound is shorthand for IsErrCode checking for 404.
+func IsErrNotFound(err error) bool {
+	return IsErrCode(err, http.StatusNotFound)
+}
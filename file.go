@@ -0,0 +1,136 @@
+package gowebdav
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// davFindBody requests the fixed set of live properties ReadDir and Stat
+// surface through os.FileInfo. Callers needing other or custom properties
+// should use PropFind instead.
+const davFindBody = `<?xml version="1.0" encoding="UTF-8"?>
+<d:propfind xmlns:d="DAV:">
+  <d:prop>
+    <d:displayname/>
+    <d:resourcetype/>
+    <d:getcontentlength/>
+    <d:getlastmodified/>
+    <d:getcontenttype/>
+    <d:getetag/>
+  </d:prop>
+</d:propfind>`
+
+type davProp struct {
+	Name        string   `xml:"prop>displayname,omitempty"`
+	Type        xml.Name `xml:"prop>resourcetype>collection"`
+	Size        string   `xml:"prop>getcontentlength,omitempty"`
+	ContentType string   `xml:"prop>getcontenttype,omitempty"`
+	ETag        string   `xml:"prop>getetag,omitempty"`
+	Modified    string   `xml:"prop>getlastmodified,omitempty"`
+	Status      string   `xml:"status"`
+}
+
+type davResponse struct {
+	Href  string    `xml:"href"`
+	Props []davProp `xml:"propstat"`
+}
+
+type davMultistatus struct {
+	Responses []davResponse `xml:"response"`
+}
+
+// File is the os.FileInfo implementation returned by ReadDir and Stat.
+type File struct {
+	name        string
+	size        int64
+	modified    time.Time
+	isdir       bool
+	etag        string
+	contentType string
+}
+
+func (f *File) Name() string { return f.name }
+func (f *File) Size() int64  { return f.size }
+func (f *File) Mode() os.FileMode {
+	if f.isdir {
+		return os.ModeDir | 0755
+	}
+	return 0644
+}
+func (f *File) ModTime() time.Time { return f.modified }
+func (f *File) IsDir() bool        { return f.isdir }
+func (f *File) Sys() any           { return nil }
+
+// ETag returns the resource's getetag property, or "" if the server didn't
+// report one.
+func (f *File) ETag() string { return f.etag }
+
+// ContentType returns the resource's getcontenttype property, or "" if the
+// server didn't report one.
+func (f *File) ContentType() string { return f.contentType }
+
+func toFile(href string, p davProp) *File {
+	isdir := p.Type.Local == "collection" || strings.HasSuffix(href, "/")
+
+	name := path.Base(strings.TrimSuffix(href, "/"))
+	if name == "" || name == "." || name == "/" {
+		name = p.Name
+	}
+
+	size, _ := strconv.ParseInt(p.Size, 10, 64)
+	modified, _ := http.ParseTime(p.Modified)
+
+	return &File{
+		name:        name,
+		size:        size,
+		modified:    modified,
+		isdir:       isdir,
+		etag:        p.ETag,
+		contentType: p.ContentType,
+	}
+}
+
+// normalizeHref strips a trailing slash so hrefs for the same resource
+// compare equal regardless of whether the server reports collections with
+// one.
+func normalizeHref(href string) string {
+	if href != "/" {
+		href = strings.TrimSuffix(href, "/")
+	}
+	return href
+}
+
+// getProps issues a PROPFIND for the fixed live-property set ReadDir/Stat
+// need, at the given Depth header value ("0" or "1").
+func (c *Client) getProps(ctx context.Context, path string, depth string) ([]davResponse, error) {
+	rs, err := c.req(ctx, "PROPFIND", path, strings.NewReader(davFindBody), func(r *http.Request) {
+		r.Header.Set("Depth", depth)
+		r.Header.Set("Content-Type", "application/xml;charset=UTF-8")
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer rs.Body.Close()
+
+	if rs.StatusCode != http.StatusMultiStatus {
+		return nil, newPathError("PROPFIND", path, rs.StatusCode)
+	}
+
+	data, err := io.ReadAll(rs.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var ms davMultistatus
+	if err := xml.Unmarshal(data, &ms); err != nil {
+		return nil, err
+	}
+	return ms.Responses, nil
+}
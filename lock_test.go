@@ -0,0 +1,156 @@
+package gowebdav
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// headerCaptureServer is a minimal test double that records the headers of
+// the last request it received and, when reject is set, fails every request
+// with 412 Precondition Failed. It doesn't implement real ETag semantics
+// (golang.org/x/net/webdav has none to exercise); it exists purely to let
+// tests assert that Client attaches the headers it's supposed to.
+type headerCaptureServer struct {
+	mu     sync.Mutex
+	header http.Header
+	reject bool
+}
+
+func (s *headerCaptureServer) lastHeader() http.Header {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.header
+}
+
+func (s *headerCaptureServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.header = r.Header.Clone()
+	reject := s.reject
+	s.mu.Unlock()
+
+	io.Copy(io.Discard, r.Body)
+
+	if reject {
+		http.Error(w, "precondition failed", http.StatusPreconditionFailed)
+		return
+	}
+
+	switch r.Method {
+	case "LOCK":
+		w.Header().Set("Lock-Token", "<urn:uuid:test-token>")
+		w.WriteHeader(http.StatusOK)
+	case "PUT", "COPY", "MOVE":
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func TestLockUnlock(t *testing.T) {
+	cli, srv, _, ctx := newServer(t)
+	defer srv.Close()
+
+	token, err := cli.Lock(ctx, "/hello.txt", LockOptions{Scope: LockExclusive, Timeout: time.Minute})
+	if err != nil {
+		t.Fatalf("got: %v, want token", err)
+	}
+	if token == "" {
+		t.Fatalf("got empty token, want a Lock-Token")
+	}
+
+	if err := cli.Unlock(ctx, "/hello.txt", token); err != nil {
+		t.Fatalf("got: %v, want nil", err)
+	}
+}
+
+func TestWithLockWrite(t *testing.T) {
+	cli, srv, fs, ctx := newServer(t)
+	defer srv.Close()
+
+	token, err := cli.Lock(ctx, "/hello.txt", LockOptions{Scope: LockExclusive, Timeout: time.Minute})
+	if err != nil {
+		t.Fatalf("got: %v, want token", err)
+	}
+	defer cli.Unlock(ctx, "/hello.txt", token)
+
+	locked := cli.WithLock(token)
+	if err := locked.Write(ctx, "/hello.txt", []byte("locked write\n"), 0644); err != nil {
+		t.Fatalf("got: %v, want nil", err)
+	}
+
+	info, err := fs.Stat(ctx, "/hello.txt")
+	if err != nil {
+		t.Fatalf("got: %v, want file info: %v", err, info)
+	}
+	if info.Size() != int64(len("locked write\n")) {
+		t.Fatalf("got size %d, want %d", info.Size(), len("locked write\n"))
+	}
+}
+
+func TestConditionalOptionsSetsHeaders(t *testing.T) {
+	backend := &headerCaptureServer{}
+	srv := httptest.NewServer(backend)
+	defer srv.Close()
+
+	cli := NewClient(srv.URL, "", "")
+	ctx := context.Background()
+	opts := ConditionalOptions{IfMatch: `"abc123"`, IfNoneMatch: "*"}
+
+	if err := cli.WriteConditional(ctx, "/a.txt", []byte("data"), 0644, opts); err != nil {
+		t.Fatalf("got: %v, want nil", err)
+	}
+	if h := backend.lastHeader(); h.Get("If-Match") != `"abc123"` || h.Get("If-None-Match") != "*" {
+		t.Fatalf("got If-Match=%q If-None-Match=%q, want %q and %q", h.Get("If-Match"), h.Get("If-None-Match"), `"abc123"`, "*")
+	}
+
+	if err := cli.CopyConditional(ctx, "/a.txt", "/b.txt", true, opts); err != nil {
+		t.Fatalf("got: %v, want nil", err)
+	}
+	if h := backend.lastHeader(); h.Get("If-Match") != `"abc123"` || h.Get("Destination") == "" || h.Get("Overwrite") != "T" {
+		t.Fatalf("got headers %v, want If-Match/Destination/Overwrite set", h)
+	}
+
+	if err := cli.RenameConditional(ctx, "/a.txt", "/c.txt", false, opts); err != nil {
+		t.Fatalf("got: %v, want nil", err)
+	}
+	if h := backend.lastHeader(); h.Get("If-Match") != `"abc123"` || h.Get("Destination") == "" || h.Get("Overwrite") != "F" {
+		t.Fatalf("got headers %v, want If-Match/Destination/Overwrite set", h)
+	}
+}
+
+func TestWriteStreamConditionalPreconditionFailed(t *testing.T) {
+	backend := &headerCaptureServer{reject: true}
+	srv := httptest.NewServer(backend)
+	defer srv.Close()
+
+	cli := NewClient(srv.URL, "", "")
+	err := cli.WriteStreamConditional(context.Background(), "/a.txt", strings.NewReader("data"), 0644, ConditionalOptions{IfMatch: `"stale"`})
+	if !IsErrCode(err, http.StatusPreconditionFailed) {
+		t.Fatalf("got: %v, want 412 error", err)
+	}
+}
+
+func TestRefreshLockSetsHeaders(t *testing.T) {
+	backend := &headerCaptureServer{}
+	srv := httptest.NewServer(backend)
+	defer srv.Close()
+
+	cli := NewClient(srv.URL, "", "")
+	if err := cli.RefreshLock(context.Background(), "/a.txt", LockToken("abc-token"), time.Minute); err != nil {
+		t.Fatalf("got: %v, want nil", err)
+	}
+
+	h := backend.lastHeader()
+	if h.Get("If") != "(<abc-token>)" {
+		t.Fatalf("got If=%q, want %q", h.Get("If"), "(<abc-token>)")
+	}
+	if h.Get("Timeout") != "Second-60" {
+		t.Fatalf("got Timeout=%q, want %q", h.Get("Timeout"), "Second-60")
+	}
+}
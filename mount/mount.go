@@ -0,0 +1,328 @@
+// Package mount composes several gowebdav.Client instances into a single
+// virtual filesystem tree, keyed by user-defined mount names. It is useful
+// for presenting many federated WebDAV shares as one browsable tree, e.g. in
+// a file manager UI.
+package mount
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/the-plate/gowebdav"
+)
+
+// Permission describes whether a mount accepts only reads or reads and
+// writes. It is enforced before any request is dispatched to the underlying
+// Client.
+type Permission int
+
+const (
+	// ReadOnly mounts reject Write, RemoveFile, RemoveAllFile, Copy (as
+	// destination) and Rename (as source or destination).
+	ReadOnly Permission = iota
+	// ReadWrite mounts accept the full Client surface.
+	ReadWrite
+)
+
+func (p Permission) String() string {
+	if p == ReadWrite {
+		return "rw"
+	}
+	return "ro"
+}
+
+// ErrReadOnly is returned when a mutating call targets a ReadOnly mount.
+var ErrReadOnly = fmt.Errorf("mount: read-only mount")
+
+// FileInfo extends os.FileInfo with the Permission of the mount the entry
+// belongs to, so callers can render affordances (e.g. grey out a write
+// button) without a second round-trip.
+type FileInfo interface {
+	os.FileInfo
+	Permission() Permission
+}
+
+type fileInfo struct {
+	os.FileInfo
+	perm Permission
+}
+
+func (fi fileInfo) Permission() Permission { return fi.perm }
+
+// mount pairs a Client with the permission it was registered under.
+type mount struct {
+	name   string
+	client *gowebdav.Client
+	perm   Permission
+}
+
+// FS is a virtual filesystem aggregating multiple gowebdav.Client mounts
+// under synthetic top-level directories named after each mount. It exposes a
+// subset of the Client surface; paths are always rooted ("/") and the first
+// path segment selects the mount.
+type FS struct {
+	mounts map[string]*mount
+	order  []string
+}
+
+// New returns an empty FS. Mounts are registered with Add.
+func New() *FS {
+	return &FS{mounts: make(map[string]*mount)}
+}
+
+// Add registers client under name with the given permission. It returns an
+// error if name is empty, already in use, or contains a "/".
+func (fs *FS) Add(name string, client *gowebdav.Client, perm Permission) error {
+	if name == "" || strings.Contains(name, "/") {
+		return fmt.Errorf("mount: invalid mount name %q", name)
+	}
+	if _, ok := fs.mounts[name]; ok {
+		return fmt.Errorf("mount: mount %q already registered", name)
+	}
+	fs.mounts[name] = &mount{name: name, client: client, perm: perm}
+	fs.order = append(fs.order, name)
+	return nil
+}
+
+// Remove unregisters the mount with the given name. It is a no-op if no such
+// mount exists.
+func (fs *FS) Remove(name string) {
+	if _, ok := fs.mounts[name]; !ok {
+		return
+	}
+	delete(fs.mounts, name)
+	for i, n := range fs.order {
+		if n == name {
+			fs.order = append(fs.order[:i], fs.order[i+1:]...)
+			break
+		}
+	}
+}
+
+// resolve splits path into the mount it addresses and the remaining,
+// mount-relative path. An empty or "/" path addresses the synthetic root and
+// returns a nil mount.
+func (fs *FS) resolve(path string) (m *mount, rest string, err error) {
+	path = strings.TrimPrefix(path, "/")
+	if path == "" {
+		return nil, "", nil
+	}
+
+	name, rest, _ := strings.Cut(path, "/")
+	m, ok := fs.mounts[name]
+	if !ok {
+		return nil, "", &os.PathError{Op: "mount", Path: path, Err: os.ErrNotExist}
+	}
+	return m, "/" + rest, nil
+}
+
+func requireReadWrite(m *mount) error {
+	if m.perm != ReadWrite {
+		return &os.PathError{Op: "mount", Path: m.name, Err: ErrReadOnly}
+	}
+	return nil
+}
+
+type rootEntry struct {
+	name string
+	perm Permission
+}
+
+func (e rootEntry) Name() string           { return e.name }
+func (e rootEntry) Size() int64            { return 0 }
+func (e rootEntry) Mode() os.FileMode      { return os.ModeDir | 0755 }
+func (e rootEntry) ModTime() time.Time     { return time.Time{} }
+func (e rootEntry) IsDir() bool            { return true }
+func (e rootEntry) Sys() any               { return nil }
+func (e rootEntry) Permission() Permission { return e.perm }
+
+// ReadDir lists the given path. ReadDir("/") returns a synthetic directory
+// entry per registered mount; any other path is proxied to the mount it
+// resolves to, with the mount prefix stripped.
+func (fs *FS) ReadDir(ctx context.Context, path string) ([]FileInfo, error) {
+	m, rest, err := fs.resolve(path)
+	if err != nil {
+		return nil, err
+	}
+	if m == nil {
+		names := append([]string(nil), fs.order...)
+		sort.Strings(names)
+		infos := make([]FileInfo, 0, len(names))
+		for _, name := range names {
+			infos = append(infos, rootEntry{name: name, perm: fs.mounts[name].perm})
+		}
+		return infos, nil
+	}
+
+	entries, err := m.client.ReadDir(ctx, rest)
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]FileInfo, len(entries))
+	for i, e := range entries {
+		infos[i] = fileInfo{FileInfo: e, perm: m.perm}
+	}
+	return infos, nil
+}
+
+// Stat returns the FileInfo for path, annotated with the Permission of the
+// mount it belongs to.
+func (fs *FS) Stat(ctx context.Context, path string) (FileInfo, error) {
+	m, rest, err := fs.resolve(path)
+	if err != nil {
+		return nil, err
+	}
+	if m == nil {
+		return rootEntry{name: "/", perm: ReadOnly}, nil
+	}
+
+	info, err := m.client.Stat(ctx, rest)
+	if err != nil {
+		return nil, err
+	}
+	return fileInfo{FileInfo: info, perm: m.perm}, nil
+}
+
+// Read returns the full contents of path.
+func (fs *FS) Read(ctx context.Context, path string) ([]byte, error) {
+	m, rest, err := fs.resolve(path)
+	if err != nil {
+		return nil, err
+	}
+	if m == nil {
+		return nil, &os.PathError{Op: "read", Path: path, Err: os.ErrInvalid}
+	}
+	return m.client.Read(ctx, rest)
+}
+
+// Write replaces the contents of path. It returns ErrReadOnly if the
+// resolved mount was registered as ReadOnly.
+func (fs *FS) Write(ctx context.Context, path string, data []byte, mode os.FileMode) error {
+	m, rest, err := fs.resolve(path)
+	if err != nil {
+		return err
+	}
+	if m == nil {
+		return &os.PathError{Op: "write", Path: path, Err: os.ErrInvalid}
+	}
+	if err := requireReadWrite(m); err != nil {
+		return err
+	}
+	return m.client.Write(ctx, rest, data, mode)
+}
+
+// RemoveFile deletes path. It returns ErrReadOnly if the resolved mount was
+// registered as ReadOnly. Named RemoveFile, rather than Remove, so it isn't
+// confused with the FS.Remove that unregisters a mount.
+func (fs *FS) RemoveFile(ctx context.Context, path string) error {
+	m, rest, err := fs.resolve(path)
+	if err != nil {
+		return err
+	}
+	if m == nil {
+		return &os.PathError{Op: "remove", Path: path, Err: os.ErrInvalid}
+	}
+	if err := requireReadWrite(m); err != nil {
+		return err
+	}
+	return m.client.Remove(ctx, rest)
+}
+
+// RemoveAllFile deletes path and, if it is a collection, everything under
+// it. It returns ErrReadOnly if the resolved mount was registered as
+// ReadOnly.
+func (fs *FS) RemoveAllFile(ctx context.Context, path string) error {
+	m, rest, err := fs.resolve(path)
+	if err != nil {
+		return err
+	}
+	if m == nil {
+		return &os.PathError{Op: "removeall", Path: path, Err: os.ErrInvalid}
+	}
+	if err := requireReadWrite(m); err != nil {
+		return err
+	}
+	return m.client.RemoveAll(ctx, rest)
+}
+
+// Copy copies oldpath to newpath. When both paths resolve to the same
+// mount, the underlying Client's native COPY is used. Otherwise, since
+// WebDAV COPY requires same-origin source and destination, Copy falls back
+// to a streaming read of oldpath followed by a write to newpath.
+func (fs *FS) Copy(ctx context.Context, oldpath, newpath string, overwrite bool) error {
+	srcMount, srcRest, err := fs.resolve(oldpath)
+	if err != nil {
+		return err
+	}
+	dstMount, dstRest, err := fs.resolve(newpath)
+	if err != nil {
+		return err
+	}
+	if srcMount == nil || dstMount == nil {
+		return &os.PathError{Op: "copy", Path: newpath, Err: os.ErrInvalid}
+	}
+	if err := requireReadWrite(dstMount); err != nil {
+		return err
+	}
+
+	if srcMount == dstMount {
+		return srcMount.client.Copy(ctx, srcRest, dstRest, overwrite)
+	}
+	return fs.crossMountCopy(ctx, srcMount, srcRest, dstMount, dstRest, overwrite)
+}
+
+// Rename moves oldpath to newpath. Like Copy, it falls back to a
+// stream-copy-then-delete when the source and destination mounts differ.
+func (fs *FS) Rename(ctx context.Context, oldpath, newpath string, overwrite bool) error {
+	srcMount, srcRest, err := fs.resolve(oldpath)
+	if err != nil {
+		return err
+	}
+	dstMount, dstRest, err := fs.resolve(newpath)
+	if err != nil {
+		return err
+	}
+	if srcMount == nil || dstMount == nil {
+		return &os.PathError{Op: "rename", Path: newpath, Err: os.ErrInvalid}
+	}
+	if err := requireReadWrite(srcMount); err != nil {
+		return err
+	}
+	if err := requireReadWrite(dstMount); err != nil {
+		return err
+	}
+
+	if srcMount == dstMount {
+		return srcMount.client.Rename(ctx, srcRest, dstRest, overwrite)
+	}
+	if err := fs.crossMountCopy(ctx, srcMount, srcRest, dstMount, dstRest, overwrite); err != nil {
+		return err
+	}
+	return srcMount.client.Remove(ctx, srcRest)
+}
+
+func (fs *FS) crossMountCopy(ctx context.Context, srcMount *mount, srcRest string, dstMount *mount, dstRest string, overwrite bool) error {
+	if !overwrite {
+		if _, err := dstMount.client.Stat(ctx, dstRest); err == nil {
+			return &os.PathError{Op: "copy", Path: dstRest, Err: os.ErrExist}
+		}
+	}
+
+	stream, err := srcMount.client.ReadStream(ctx, srcRest)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	info, err := srcMount.client.Stat(ctx, srcRest)
+	if err != nil {
+		return err
+	}
+
+	return dstMount.client.WriteStream(ctx, dstRest, io.Reader(stream), info.Mode())
+}
@@ -0,0 +1,141 @@
+package mount
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"golang.org/x/net/webdav"
+
+	"github.com/the-plate/gowebdav"
+)
+
+func newMountServer(t *testing.T) (*gowebdav.Client, *httptest.Server) {
+	fs := webdav.NewMemFS()
+	ctx := context.Background()
+	f, err := fs.OpenFile(ctx, "hello.txt", os.O_CREATE, 0644)
+	if err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+	f.Write([]byte("hello gowebdav\n"))
+	f.Close()
+
+	srv := httptest.NewServer(&webdav.Handler{FileSystem: fs, LockSystem: webdav.NewMemLS()})
+	cli := gowebdav.NewClient(srv.URL, "", "")
+	return cli, srv
+}
+
+func TestFSReadDirRoot(t *testing.T) {
+	clia, srva := newMountServer(t)
+	defer srva.Close()
+	clib, srvb := newMountServer(t)
+	defer srvb.Close()
+
+	fs := New()
+	if err := fs.Add("a", clia, ReadWrite); err != nil {
+		t.Fatalf("got: %v, want nil", err)
+	}
+	if err := fs.Add("b", clib, ReadOnly); err != nil {
+		t.Fatalf("got: %v, want nil", err)
+	}
+
+	entries, err := fs.ReadDir(context.Background(), "/")
+	if err != nil {
+		t.Fatalf("got: %v, want entries", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2: %v", len(entries), entries)
+	}
+	if entries[0].Name() != "a" || entries[1].Name() != "b" {
+		t.Fatalf("got: %v, want mounts a, b", entries)
+	}
+}
+
+func TestFSProxiesToMount(t *testing.T) {
+	clia, srva := newMountServer(t)
+	defer srva.Close()
+
+	fs := New()
+	if err := fs.Add("a", clia, ReadWrite); err != nil {
+		t.Fatalf("got: %v, want nil", err)
+	}
+
+	data, err := fs.Read(context.Background(), "/a/hello.txt")
+	if err != nil || string(data) != "hello gowebdav\n" {
+		t.Fatalf("got: %v %q, want data: %q", err, data, "hello gowebdav\n")
+	}
+}
+
+func TestFSReadOnlyRejectsWrite(t *testing.T) {
+	clia, srva := newMountServer(t)
+	defer srva.Close()
+
+	fs := New()
+	if err := fs.Add("a", clia, ReadOnly); err != nil {
+		t.Fatalf("got: %v, want nil", err)
+	}
+
+	err := fs.Write(context.Background(), "/a/new.txt", []byte("nope"), 0644)
+	if err == nil {
+		t.Fatalf("got nil, want ErrReadOnly")
+	}
+}
+
+func TestFSRemoveFile(t *testing.T) {
+	clia, srva := newMountServer(t)
+	defer srva.Close()
+
+	fs := New()
+	if err := fs.Add("a", clia, ReadWrite); err != nil {
+		t.Fatalf("got: %v, want nil", err)
+	}
+
+	ctx := context.Background()
+	if err := fs.RemoveFile(ctx, "/a/hello.txt"); err != nil {
+		t.Fatalf("got: %v, want nil", err)
+	}
+	if _, err := fs.Stat(ctx, "/a/hello.txt"); err == nil {
+		t.Fatalf("got nil, want error after RemoveFile")
+	}
+}
+
+func TestFSReadOnlyRejectsRemoveFile(t *testing.T) {
+	clia, srva := newMountServer(t)
+	defer srva.Close()
+
+	fs := New()
+	if err := fs.Add("a", clia, ReadOnly); err != nil {
+		t.Fatalf("got: %v, want nil", err)
+	}
+
+	err := fs.RemoveFile(context.Background(), "/a/hello.txt")
+	if err == nil {
+		t.Fatalf("got nil, want ErrReadOnly")
+	}
+}
+
+func TestFSCrossMountCopy(t *testing.T) {
+	clia, srva := newMountServer(t)
+	defer srva.Close()
+	clib, srvb := newMountServer(t)
+	defer srvb.Close()
+
+	fs := New()
+	if err := fs.Add("a", clia, ReadWrite); err != nil {
+		t.Fatalf("got: %v, want nil", err)
+	}
+	if err := fs.Add("b", clib, ReadWrite); err != nil {
+		t.Fatalf("got: %v, want nil", err)
+	}
+
+	ctx := context.Background()
+	if err := fs.Copy(ctx, "/a/hello.txt", "/b/copy.txt", false); err != nil {
+		t.Fatalf("got: %v, want nil", err)
+	}
+
+	data, err := fs.Read(ctx, "/b/copy.txt")
+	if err != nil || string(data) != "hello gowebdav\n" {
+		t.Fatalf("got: %v %q, want data: %q", err, data, "hello gowebdav\n")
+	}
+}
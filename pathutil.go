@@ -0,0 +1,28 @@
+package gowebdav
+
+import (
+	"net/url"
+	"strings"
+)
+
+// segmentEscape percent-encodes each "/"-separated segment of p
+// independently, so literal slashes in p keep their meaning as path
+// separators.
+func segmentEscape(p string) string {
+	segments := strings.Split(p, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
+
+// parentDir returns the parent of p, which must be rooted ("/..."). The
+// parent of "/" and of any single top-level segment is "/".
+func parentDir(p string) string {
+	p = strings.TrimSuffix(p, "/")
+	i := strings.LastIndex(p, "/")
+	if i <= 0 {
+		return "/"
+	}
+	return p[:i]
+}
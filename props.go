@@ -0,0 +1,252 @@
+package gowebdav
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Property is a single WebDAV property to set via PropPatch. InnerXML carries
+// the already-marshalled value, allowing arbitrary nested XML (e.g. a
+// urn:ietf:params:xml:ns:caldav calendar-data blob) without this package
+// needing to know its schema.
+type Property struct {
+	Name     xml.Name
+	InnerXML []byte
+}
+
+// Response is the parsed multistatus entry for a single href returned by
+// PropFind.
+type Response struct {
+	Href  string
+	Props []ResponseProp
+}
+
+// ResponseProp is a single property value returned for a Response, together
+// with the per-prop status reported by the server (e.g. "HTTP/1.1 404 Not
+// Found" for a requested-but-absent dead property).
+type ResponseProp struct {
+	Name     xml.Name
+	InnerXML []byte
+	Status   string
+}
+
+// HasProperty reports whether the Response carries a value for the property
+// identified by ns/local, regardless of its status.
+func (r Response) HasProperty(ns, local string) bool {
+	for _, p := range r.Props {
+		if p.Name.Space == ns && p.Name.Local == local {
+			return true
+		}
+	}
+	return false
+}
+
+// GetString returns the trimmed character data of the property identified by
+// ns/local, or "" if the Response has no such property.
+func (r Response) GetString(ns, local string) string {
+	for _, p := range r.Props {
+		if p.Name.Space == ns && p.Name.Local == local {
+			var text bytes.Buffer
+			d := xml.NewDecoder(bytes.NewReader(p.InnerXML))
+			for {
+				tok, err := d.Token()
+				if err != nil {
+					break
+				}
+				if cd, ok := tok.(xml.CharData); ok {
+					text.Write(cd)
+				}
+			}
+			return string(bytes.TrimSpace(text.Bytes()))
+		}
+	}
+	return ""
+}
+
+type xmlProp struct {
+	XMLName  xml.Name
+	InnerXML []byte `xml:",innerxml"`
+}
+
+type xmlPropStat struct {
+	Prop struct {
+		Items []xmlProp `xml:",any"`
+	} `xml:"prop"`
+	Status string `xml:"status"`
+}
+
+type xmlResponse struct {
+	Href     string        `xml:"href"`
+	PropStat []xmlPropStat `xml:"propstat"`
+}
+
+type xmlMultiStatus struct {
+	XMLName  xml.Name      `xml:"DAV: multistatus"`
+	Response []xmlResponse `xml:"response"`
+}
+
+// validLocalName reports whether s is safe to interpolate as an XML element
+// name: non-empty and free of characters that would break tag syntax or let
+// a caller-supplied name smuggle extra markup into the request body.
+func validLocalName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		switch r {
+		case '<', '>', '&', '"', '\'', '/', ' ', '\t', '\n', '\r':
+			return false
+		}
+	}
+	return true
+}
+
+// escapeAttr escapes s for safe use as the value of a double-quoted XML
+// attribute (e.g. the xmlns on a hand-built <prop> element).
+func escapeAttr(s string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
+
+func marshalPropNames(props []xml.Name) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	for _, p := range props {
+		if !validLocalName(p.Local) {
+			return nil, fmt.Errorf("gowebdav: invalid property local name %q", p.Local)
+		}
+		fmt.Fprintf(buf, "<%s xmlns=\"%s\"/>", p.Local, escapeAttr(p.Space))
+	}
+	return buf.Bytes(), nil
+}
+
+// PropFind issues a PROPFIND request against path with the given Depth
+// header (0 or 1; any negative value sends "infinity") and returns one
+// Response per href in the resulting multistatus. If props is empty, an
+// <allprop/> request is sent; otherwise a <prop/> request naming exactly
+// those properties is sent, which is the only way to retrieve custom dead
+// properties not baked into ReadDir/Stat's fixed live-property set.
+func (c *Client) PropFind(ctx context.Context, path string, depth int, props []xml.Name) ([]Response, error) {
+	var body bytes.Buffer
+	body.WriteString(`<?xml version="1.0" encoding="UTF-8"?><propfind xmlns="DAV:">`)
+	if len(props) == 0 {
+		body.WriteString(`<allprop/>`)
+	} else {
+		body.WriteString(`<prop>`)
+		names, err := marshalPropNames(props)
+		if err != nil {
+			return nil, err
+		}
+		body.Write(names)
+		body.WriteString(`</prop>`)
+	}
+	body.WriteString(`</propfind>`)
+
+	depthHeader := "infinity"
+	if depth >= 0 {
+		depthHeader = fmt.Sprintf("%d", depth)
+	}
+
+	rs, err := c.req(ctx, "PROPFIND", path, &body, func(r *http.Request) {
+		r.Header.Set("Depth", depthHeader)
+		r.Header.Set("Content-Type", "application/xml;charset=UTF-8")
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer rs.Body.Close()
+
+	if rs.StatusCode != http.StatusMultiStatus {
+		return nil, newPathError("PropFind", path, rs.StatusCode)
+	}
+
+	data, err := io.ReadAll(rs.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var ms xmlMultiStatus
+	if err := xml.Unmarshal(data, &ms); err != nil {
+		return nil, fmt.Errorf("gowebdav: parsing PROPFIND response: %w", err)
+	}
+
+	responses := make([]Response, 0, len(ms.Response))
+	for _, xr := range ms.Response {
+		resp := Response{Href: xr.Href}
+		for _, ps := range xr.PropStat {
+			for _, p := range ps.Prop.Items {
+				resp.Props = append(resp.Props, ResponseProp{
+					Name:     p.XMLName,
+					InnerXML: p.InnerXML,
+					Status:   ps.Status,
+				})
+			}
+		}
+		responses = append(responses, resp)
+	}
+
+	return responses, nil
+}
+
+// PropPatch issues a PROPPATCH request against path, setting each Property
+// in set and removing each xml.Name in remove, in the order given. Dead
+// properties stored this way are not surfaced by ReadDir/Stat but can be
+// retrieved again with PropFind.
+func (c *Client) PropPatch(ctx context.Context, path string, set []Property, remove []xml.Name) error {
+	return c.propPatch(ctx, path, set, remove, nil)
+}
+
+// propPatch is the shared implementation behind PropPatch and
+// LockedClient.PropPatch; intercept, if non-nil, is called after the
+// Content-Type header is set, letting callers layer in extra headers (e.g.
+// an If header carrying a lock token).
+func (c *Client) propPatch(ctx context.Context, path string, set []Property, remove []xml.Name, intercept func(*http.Request)) error {
+	if len(set) == 0 && len(remove) == 0 {
+		return nil
+	}
+
+	var body bytes.Buffer
+	body.WriteString(`<?xml version="1.0" encoding="UTF-8"?><propertyupdate xmlns="DAV:">`)
+	if len(set) > 0 {
+		body.WriteString(`<set><prop>`)
+		for _, p := range set {
+			if !validLocalName(p.Name.Local) {
+				return fmt.Errorf("gowebdav: invalid property local name %q", p.Name.Local)
+			}
+			fmt.Fprintf(&body, "<%s xmlns=\"%s\">", p.Name.Local, escapeAttr(p.Name.Space))
+			body.Write(p.InnerXML)
+			fmt.Fprintf(&body, "</%s>", p.Name.Local)
+		}
+		body.WriteString(`</prop></set>`)
+	}
+	if len(remove) > 0 {
+		names, err := marshalPropNames(remove)
+		if err != nil {
+			return err
+		}
+		body.WriteString(`<remove><prop>`)
+		body.Write(names)
+		body.WriteString(`</prop></remove>`)
+	}
+	body.WriteString(`</propertyupdate>`)
+
+	rs, err := c.req(ctx, "PROPPATCH", path, &body, func(r *http.Request) {
+		r.Header.Set("Content-Type", "application/xml;charset=UTF-8")
+		if intercept != nil {
+			intercept(r)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	defer rs.Body.Close()
+
+	if rs.StatusCode != http.StatusMultiStatus {
+		return newPathError("PropPatch", path, rs.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package gowebdav
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+func TestPropFind(t *testing.T) {
+	cli, srv, _, ctx := newServer(t)
+	defer srv.Close()
+
+	resps, err := cli.PropFind(ctx, "/hello.txt", 0, []xml.Name{{Space: "DAV:", Local: "getcontentlength"}})
+	if err != nil {
+		t.Fatalf("got: %v, want responses", err)
+	}
+	if len(resps) != 1 {
+		t.Fatalf("got %d responses, want 1: %v", len(resps), resps)
+	}
+	if !resps[0].HasProperty("DAV:", "getcontentlength") {
+		t.Fatalf("got: %v, want getcontentlength property", resps[0])
+	}
+	if resps[0].GetString("DAV:", "getcontentlength") != "15" {
+		t.Fatalf("got: %q, want: %q", resps[0].GetString("DAV:", "getcontentlength"), "15")
+	}
+}
+
+func TestPropFindAllProp(t *testing.T) {
+	cli, srv, _, ctx := newServer(t)
+	defer srv.Close()
+
+	resps, err := cli.PropFind(ctx, "/", 1, nil)
+	if err != nil {
+		t.Fatalf("got: %v, want responses", err)
+	}
+	if len(resps) < 2 {
+		t.Fatalf("got %d responses, want at least 2: %v", len(resps), resps)
+	}
+}
+
+func TestPropPatch(t *testing.T) {
+	cli, srv, _, ctx := newServer(t)
+	defer srv.Close()
+
+	custom := xml.Name{Space: "urn:gowebdav:test", Local: "label"}
+	err := cli.PropPatch(ctx, "/hello.txt", []Property{{Name: custom, InnerXML: []byte("demo")}}, nil)
+	if err != nil {
+		t.Fatalf("got: %v, want nil", err)
+	}
+}
@@ -0,0 +1,44 @@
+package gowebdav
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestReadStreamRanges(t *testing.T) {
+	cli, srv, _, ctx := newServer(t)
+	defer srv.Close()
+
+	parts, err := cli.ReadStreamRanges(ctx, "/hello.txt", []Range{
+		{Start: 0, Length: 5},
+		{Start: 6, Length: 3},
+	})
+	if err != nil {
+		t.Fatalf("got: %v, want parts: %v", err, parts)
+	}
+	if len(parts) != 2 {
+		t.Fatalf("got %d parts, want 2: %v", len(parts), parts)
+	}
+
+	want := []string{"hello", "gow"}
+	for i, p := range parts {
+		buf := new(bytes.Buffer)
+		buf.ReadFrom(p.Body)
+		if buf.String() != want[i] {
+			t.Fatalf("part %d: got %q, want %q", i, buf.String(), want[i])
+		}
+	}
+}
+
+func TestReadStreamRangesNotSatisfiable(t *testing.T) {
+	cli, srv, _, ctx := newServer(t)
+	defer srv.Close()
+
+	_, err := cli.ReadStreamRanges(ctx, "/hello.txt", []Range{{Start: 1000, Length: 10}})
+	if err == nil {
+		t.Fatalf("got nil, want error")
+	}
+	if !IsErrRangeNotSatisfiable(err) {
+		t.Fatalf("got: %v, want 416 error", err)
+	}
+}
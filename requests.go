@@ -0,0 +1,100 @@
+package gowebdav
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Client is a WebDAV client bound to a single server root. It is safe for
+// concurrent use.
+type Client struct {
+	root    string
+	headers http.Header
+	c       *http.Client
+
+	user, pw string
+
+	bufferPool BufferPool
+}
+
+// NewClient returns a Client rooted at uri, authenticating with HTTP Basic
+// auth using user/pw. Either may be empty to skip authentication.
+func NewClient(uri, user, pw string) *Client {
+	return &Client{
+		root:    strings.TrimSuffix(uri, "/"),
+		headers: make(http.Header),
+		c:       &http.Client{},
+		user:    user,
+		pw:      pw,
+	}
+}
+
+// SetHeader sets a header sent with every subsequent request.
+func (c *Client) SetHeader(key, value string) {
+	c.headers.Set(key, value)
+}
+
+// SetTransport sets the http.RoundTripper used for requests.
+func (c *Client) SetTransport(transport http.RoundTripper) {
+	c.c.Transport = transport
+}
+
+// SetJar sets the cookie jar used for requests.
+func (c *Client) SetJar(jar http.CookieJar) {
+	c.c.Jar = jar
+}
+
+// SetTimeout sets the per-request timeout.
+func (c *Client) SetTimeout(timeout time.Duration) {
+	c.c.Timeout = timeout
+}
+
+// Connect verifies that path "/" is reachable with the configured
+// credentials by issuing a zero-depth PROPFIND.
+func (c *Client) Connect() error {
+	rs, err := c.req(context.Background(), "PROPFIND", "/", nil, func(r *http.Request) {
+		r.Header.Set("Depth", "0")
+	})
+	if err != nil {
+		return err
+	}
+	defer rs.Body.Close()
+
+	if rs.StatusCode != http.StatusMultiStatus {
+		return newPathError("Connect", "/", rs.StatusCode)
+	}
+	return nil
+}
+
+// abs returns the absolute URL of path under the Client's root, with each
+// path segment percent-escaped independently.
+func (c *Client) abs(path string) string {
+	return c.root + segmentEscape(path)
+}
+
+// req issues an HTTP request against path using method, with body as the
+// request body (nil for none). intercept, if non-nil, runs after the
+// Client's own headers and auth are set and before the request is sent,
+// letting callers layer in per-request headers (Depth, Destination, If,
+// Range, Content-Range, ...).
+func (c *Client) req(ctx context.Context, method, path string, body io.Reader, intercept func(*http.Request)) (*http.Response, error) {
+	rq, err := http.NewRequestWithContext(ctx, method, c.abs(path), body)
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range c.headers {
+		rq.Header[k] = v
+	}
+	if c.user != "" {
+		rq.SetBasicAuth(c.user, c.pw)
+	}
+	if intercept != nil {
+		intercept(rq)
+	}
+
+	return c.c.Do(rq)
+}
@@ -0,0 +1,293 @@
+package gowebdav
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// defaultChunkSize is used by WriteStreamResumable when ResumeOptions.ChunkSize
+// is left at zero.
+const defaultChunkSize = 8 * 1024 * 1024
+
+// HashAlgorithm selects the client-side verification hash used by
+// WriteStreamResumable.
+type HashAlgorithm int
+
+const (
+	HashNone HashAlgorithm = iota
+	HashMD5
+	HashSHA256
+)
+
+func (h HashAlgorithm) new() hash.Hash {
+	switch h {
+	case HashMD5:
+		return md5.New()
+	case HashSHA256:
+		return sha256.New()
+	default:
+		return nil
+	}
+}
+
+// ResumeStore remembers how many bytes of path have been uploaded so a
+// resumed WriteStreamResumable call knows where to pick up. The store
+// returned by NewMemoryResumeStore is safe for concurrent use; embedders
+// needing persistence across process restarts (e.g. BoltDB or a plain file)
+// implement this interface themselves.
+type ResumeStore interface {
+	// Progress returns the number of bytes of path known to have been
+	// uploaded, and whether any progress has been recorded at all.
+	Progress(path string) (offset int64, ok bool)
+	// SetProgress records that offset bytes of path have been uploaded.
+	SetProgress(path string, offset int64)
+	// Clear removes any recorded progress for path, e.g. after a
+	// successful upload.
+	Clear(path string)
+}
+
+type memoryResumeStore struct {
+	mu       sync.Mutex
+	progress map[string]int64
+}
+
+// NewMemoryResumeStore returns a ResumeStore backed by an in-memory map. It
+// does not survive process restarts.
+func NewMemoryResumeStore() ResumeStore {
+	return &memoryResumeStore{progress: make(map[string]int64)}
+}
+
+func (s *memoryResumeStore) Progress(path string) (int64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	off, ok := s.progress[path]
+	return off, ok
+}
+
+func (s *memoryResumeStore) SetProgress(path string, offset int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.progress[path] = offset
+}
+
+func (s *memoryResumeStore) Clear(path string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.progress, path)
+}
+
+// ResumeOptions configures WriteStreamResumable.
+type ResumeOptions struct {
+	// ChunkSize is the size of each Content-Range PUT; it defaults to 8 MiB.
+	ChunkSize int64
+	// Store remembers per-path upload progress across calls/reconnects; it
+	// defaults to an ephemeral NewMemoryResumeStore.
+	Store ResumeStore
+	// Verify, if not HashNone, computes a client-side hash of the bytes
+	// that were uploaded and compares it against the server's Content-MD5
+	// response header (for HashMD5) or {DAV:}getcontenthash property (for
+	// HashSHA256). Because r is a single-pass stream that has already been
+	// fully consumed by the time the hash is known, a mismatch cannot be
+	// retried chunk-by-chunk; WriteStreamResumable instead clears the
+	// upload's recorded progress and returns an error, so a caller that
+	// retries with a fresh reader re-uploads from scratch rather than
+	// getting stuck believing the (corrupt) upload is complete.
+	Verify HashAlgorithm
+	// OnProgress, if set, is called after each chunk is successfully
+	// uploaded with the total bytes written so far and the total size.
+	OnProgress func(written, total int64)
+}
+
+// WriteStreamResumable uploads r, which must yield exactly size bytes, to
+// path in fixed-size chunks using Content-Range PUTs in the style supported
+// by Sabre/dav and Nextcloud. If opts.Store already has progress recorded
+// for path, upload resumes from that recorded offset, capped at the server's
+// reported current size, rather than starting over, making multi-gigabyte uploads survivable across
+// reconnects on flaky links.
+func (c *Client) WriteStreamResumable(ctx context.Context, path string, r io.Reader, size int64, opts ResumeOptions) error {
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = defaultChunkSize
+	}
+	store := opts.Store
+	if store == nil {
+		store = NewMemoryResumeStore()
+	}
+
+	switch opts.Verify {
+	case HashNone, HashMD5, HashSHA256:
+	default:
+		return fmt.Errorf("gowebdav: unknown HashAlgorithm %d", opts.Verify)
+	}
+
+	offset, err := c.resumeOffset(ctx, path, store)
+	if err != nil {
+		return err
+	}
+	if opts.Verify != HashNone && offset > 0 {
+		return fmt.Errorf("gowebdav: resuming with content verification requires re-hashing already-uploaded bytes, which is not supported; clear ResumeOptions.Store progress for %q first", path)
+	}
+	if offset > 0 {
+		if _, err := io.CopyN(io.Discard, r, offset); err != nil {
+			return fmt.Errorf("gowebdav: seeking resumable upload to offset %d: %w", offset, err)
+		}
+	}
+
+	h := opts.Verify.new()
+
+	if size == 0 {
+		if status, err := c.putFile(ctx, path, nil); err != nil {
+			return err
+		} else if status < 200 || status >= 300 {
+			return newPathError("WriteStreamResumable", path, status)
+		}
+		store.Clear(path)
+		return nil
+	}
+
+	for offset < size {
+		n := chunkSize
+		if remaining := size - offset; remaining < n {
+			n = remaining
+		}
+
+		chunk := io.LimitReader(r, n)
+		if h != nil {
+			chunk = io.TeeReader(chunk, h)
+		}
+
+		buf := new(bytes.Buffer)
+		if _, err := c.copyBuffer(buf, chunk); err != nil {
+			return err
+		}
+		if int64(buf.Len()) != n {
+			return fmt.Errorf("gowebdav: short read uploading %q: got %d bytes, want %d", path, buf.Len(), n)
+		}
+
+		if err := c.putChunk(ctx, path, buf.Bytes(), offset, size); err != nil {
+			return err
+		}
+
+		offset += n
+		store.SetProgress(path, offset)
+		if opts.OnProgress != nil {
+			opts.OnProgress(offset, size)
+		}
+	}
+
+	if h != nil {
+		if err := c.verifyUploadHash(ctx, path, opts.Verify, h); err != nil {
+			// r has already been fully consumed, so the mismatched bytes
+			// can't be re-sent here; clear the recorded progress so a
+			// caller that retries with a fresh reader starts over instead
+			// of resumeOffset reporting the (corrupt) upload as complete.
+			store.Clear(path)
+			return err
+		}
+	}
+
+	store.Clear(path)
+	return nil
+}
+
+// resumeOffset returns the offset to resume path's upload from: the store's
+// recorded progress if any, confirmed against (and capped by) the server's
+// actual current size, or 0 for a fresh upload.
+func (c *Client) resumeOffset(ctx context.Context, path string, store ResumeStore) (int64, error) {
+	offset, ok := store.Progress(path)
+	if !ok {
+		return 0, nil
+	}
+
+	info, err := c.Stat(ctx, path)
+	if err != nil {
+		if IsErrNotFound(err) {
+			store.Clear(path)
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	if info.Size() < offset {
+		offset = info.Size()
+	}
+	return offset, nil
+}
+
+func (c *Client) putChunk(ctx context.Context, path string, data []byte, offset, total int64) error {
+	rs, err := c.req(ctx, "PUT", path, bytes.NewReader(data), func(r *http.Request) {
+		r.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, offset+int64(len(data))-1, total))
+	})
+	if err != nil {
+		return err
+	}
+	defer rs.Body.Close()
+
+	if rs.StatusCode < 200 || rs.StatusCode >= 300 {
+		return newPathError("WriteStreamResumable", path, rs.StatusCode)
+	}
+	return nil
+}
+
+func (c *Client) verifyUploadHash(ctx context.Context, path string, algo HashAlgorithm, h hash.Hash) error {
+	if algo == HashSHA256 {
+		return c.verifyContentHashProp(ctx, path, "getcontenthash", hex.EncodeToString(h.Sum(nil)))
+	}
+	return c.verifyContentMD5(ctx, path, h.Sum(nil))
+}
+
+// verifyContentMD5 compares sum against the server's Content-MD5 response
+// header, which RFC 1864 defines as the base64 encoding of the raw digest
+// bytes (not hex).
+func (c *Client) verifyContentMD5(ctx context.Context, path string, sum []byte) error {
+	rs, err := c.req(ctx, "HEAD", path, nil, func(*http.Request) {})
+	if err != nil {
+		return err
+	}
+	defer rs.Body.Close()
+
+	if rs.StatusCode != http.StatusOK {
+		return newPathError("verifyContentMD5", path, rs.StatusCode)
+	}
+
+	header := rs.Header.Get("Content-MD5")
+	if header == "" {
+		return fmt.Errorf("gowebdav: verifying upload of %q: server did not return a Content-MD5 header", path)
+	}
+	got, err := base64.StdEncoding.DecodeString(header)
+	if err != nil {
+		return fmt.Errorf("gowebdav: verifying upload of %q: malformed Content-MD5 header %q: %w", path, header, err)
+	}
+	if !bytes.Equal(got, sum) {
+		return fmt.Errorf("gowebdav: upload verification failed for %q: got Content-MD5 %x, want %x", path, got, sum)
+	}
+	return nil
+}
+
+// verifyContentHashProp compares want against the given hex-encoded DAV
+// property on path, as fetched via PropFind.
+func (c *Client) verifyContentHashProp(ctx context.Context, path, local, want string) error {
+	resps, err := c.PropFind(ctx, path, 0, []xml.Name{{Space: "DAV:", Local: local}})
+	if err != nil {
+		return err
+	}
+	if len(resps) == 0 {
+		return fmt.Errorf("gowebdav: verifying upload of %q: no PROPFIND response", path)
+	}
+
+	got := resps[0].GetString("DAV:", local)
+	if got != want {
+		return fmt.Errorf("gowebdav: upload verification failed for %q: got hash %q, want %q", path, got, want)
+	}
+	return nil
+}
@@ -0,0 +1,247 @@
+package gowebdav
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// chunkedPutServer is a minimal test double that accepts Content-Range PUTs
+// Sabre/dav-style and assembles them into an in-memory file, so
+// WriteStreamResumable can be exercised without a full WebDAV server.
+// forceHash, if set, is reported as the Content-MD5/getcontenthash value
+// instead of the real hash of data, simulating server-side corruption that
+// client-side verification should catch.
+type chunkedPutServer struct {
+	mu        sync.Mutex
+	data      []byte
+	nputs     int
+	forceHash []byte
+}
+
+var contentRangePutRE = regexp.MustCompile(`^bytes (\d+)-(\d+)/(\d+)$`)
+
+func (s *chunkedPutServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "PUT":
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.nputs++
+
+		body, _ := io.ReadAll(r.Body)
+		cr := r.Header.Get("Content-Range")
+		if cr == "" {
+			s.data = body
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+
+		m := contentRangePutRE.FindStringSubmatch(cr)
+		if m == nil {
+			http.Error(w, "bad Content-Range", http.StatusBadRequest)
+			return
+		}
+		start, _ := strconv.ParseInt(m[1], 10, 64)
+		total, _ := strconv.ParseInt(m[3], 10, 64)
+		if int64(len(s.data)) < total {
+			grown := make([]byte, total)
+			copy(grown, s.data)
+			s.data = grown
+		}
+		copy(s.data[start:], body)
+		w.WriteHeader(http.StatusCreated)
+	case "PROPFIND":
+		sum := sha256.Sum256(s.data)
+		hash := s.forceHash
+		if hash == nil {
+			hash = sum[:]
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(http.StatusMultiStatus)
+		w.Write([]byte(`<?xml version="1.0"?><D:multistatus xmlns:D="DAV:">` +
+			`<D:response><D:href>/file.bin</D:href><D:propstat><D:prop>` +
+			`<D:getcontentlength>` + strconv.Itoa(len(s.data)) + `</D:getcontentlength>` +
+			`<D:getcontenthash>` + hex.EncodeToString(hash) + `</D:getcontenthash>` +
+			`</D:prop><D:status>HTTP/1.1 200 OK</D:status></D:propstat></D:response>` +
+			`</D:multistatus>`))
+	case "HEAD":
+		sum := md5.Sum(s.data)
+		hash := s.forceHash
+		if hash == nil {
+			hash = sum[:]
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(s.data)))
+		w.Header().Set("Content-MD5", base64.StdEncoding.EncodeToString(hash))
+		w.WriteHeader(http.StatusOK)
+	default:
+		http.Error(w, "unsupported", http.StatusMethodNotAllowed)
+	}
+}
+
+func TestWriteStreamResumable(t *testing.T) {
+	backend := &chunkedPutServer{}
+	srv := httptest.NewServer(backend)
+	defer srv.Close()
+
+	cli := NewClient(srv.URL, "", "")
+
+	payload := bytes.Repeat([]byte("gowebdav-chunk-"), 100)
+	var progressed int64
+	opts := ResumeOptions{
+		ChunkSize: 256,
+		OnProgress: func(written, total int64) {
+			progressed = written
+			if total != int64(len(payload)) {
+				t.Fatalf("got total %d, want %d", total, len(payload))
+			}
+		},
+	}
+
+	if err := cli.WriteStreamResumable(context.Background(), "/file.bin", bytes.NewReader(payload), int64(len(payload)), opts); err != nil {
+		t.Fatalf("got: %v, want nil", err)
+	}
+	if progressed != int64(len(payload)) {
+		t.Fatalf("got progress %d, want %d", progressed, len(payload))
+	}
+
+	backend.mu.Lock()
+	got := append([]byte(nil), backend.data...)
+	backend.mu.Unlock()
+	if !bytes.Equal(got, payload) {
+		t.Fatalf("got %d bytes, want %d matching bytes", len(got), len(payload))
+	}
+}
+
+func TestWriteStreamResumableResumesFromStore(t *testing.T) {
+	backend := &chunkedPutServer{}
+	srv := httptest.NewServer(backend)
+	defer srv.Close()
+
+	cli := NewClient(srv.URL, "", "")
+
+	payload := bytes.Repeat([]byte("x"), 1000)
+	store := NewMemoryResumeStore()
+	store.SetProgress("/file.bin", 400)
+	backend.data = append([]byte(nil), payload[:400]...)
+
+	if err := cli.WriteStreamResumable(context.Background(), "/file.bin", bytes.NewReader(payload), int64(len(payload)), ResumeOptions{
+		ChunkSize: 100,
+		Store:     store,
+	}); err != nil {
+		t.Fatalf("got: %v, want nil", err)
+	}
+
+	if !bytes.Equal(backend.data, payload) {
+		t.Fatalf("got %d bytes, want %d matching bytes", len(backend.data), len(payload))
+	}
+	if _, ok := store.Progress("/file.bin"); ok {
+		t.Fatalf("want progress cleared after successful upload")
+	}
+}
+
+func TestWriteStreamResumableVerifyMD5(t *testing.T) {
+	backend := &chunkedPutServer{}
+	srv := httptest.NewServer(backend)
+	defer srv.Close()
+
+	cli := NewClient(srv.URL, "", "")
+	payload := bytes.Repeat([]byte("gowebdav-chunk-"), 100)
+
+	if err := cli.WriteStreamResumable(context.Background(), "/file.bin", bytes.NewReader(payload), int64(len(payload)), ResumeOptions{
+		ChunkSize: 256,
+		Verify:    HashMD5,
+	}); err != nil {
+		t.Fatalf("got: %v, want nil", err)
+	}
+}
+
+func TestWriteStreamResumableVerifyMD5Mismatch(t *testing.T) {
+	backend := &chunkedPutServer{forceHash: bytes.Repeat([]byte{0xff}, md5.Size)}
+	srv := httptest.NewServer(backend)
+	defer srv.Close()
+
+	cli := NewClient(srv.URL, "", "")
+	payload := bytes.Repeat([]byte("gowebdav-chunk-"), 100)
+	store := NewMemoryResumeStore()
+
+	err := cli.WriteStreamResumable(context.Background(), "/file.bin", bytes.NewReader(payload), int64(len(payload)), ResumeOptions{
+		ChunkSize: 256,
+		Verify:    HashMD5,
+		Store:     store,
+	})
+	if err == nil {
+		t.Fatalf("got nil, want hash mismatch error")
+	}
+	if _, ok := store.Progress("/file.bin"); ok {
+		t.Fatalf("want progress cleared after a verify failure")
+	}
+}
+
+func TestWriteStreamResumableVerifySHA256(t *testing.T) {
+	backend := &chunkedPutServer{}
+	srv := httptest.NewServer(backend)
+	defer srv.Close()
+
+	cli := NewClient(srv.URL, "", "")
+	payload := bytes.Repeat([]byte("gowebdav-chunk-"), 100)
+
+	if err := cli.WriteStreamResumable(context.Background(), "/file.bin", bytes.NewReader(payload), int64(len(payload)), ResumeOptions{
+		ChunkSize: 256,
+		Verify:    HashSHA256,
+	}); err != nil {
+		t.Fatalf("got: %v, want nil", err)
+	}
+}
+
+func TestWriteStreamResumableVerifySHA256Mismatch(t *testing.T) {
+	backend := &chunkedPutServer{forceHash: bytes.Repeat([]byte{0xff}, sha256.Size)}
+	srv := httptest.NewServer(backend)
+	defer srv.Close()
+
+	cli := NewClient(srv.URL, "", "")
+	payload := bytes.Repeat([]byte("gowebdav-chunk-"), 100)
+	store := NewMemoryResumeStore()
+
+	err := cli.WriteStreamResumable(context.Background(), "/file.bin", bytes.NewReader(payload), int64(len(payload)), ResumeOptions{
+		ChunkSize: 256,
+		Verify:    HashSHA256,
+		Store:     store,
+	})
+	if err == nil {
+		t.Fatalf("got nil, want hash mismatch error")
+	}
+	if _, ok := store.Progress("/file.bin"); ok {
+		t.Fatalf("want progress cleared after a verify failure")
+	}
+}
+
+func TestWriteStreamResumableZeroLength(t *testing.T) {
+	backend := &chunkedPutServer{}
+	srv := httptest.NewServer(backend)
+	defer srv.Close()
+
+	cli := NewClient(srv.URL, "", "")
+
+	if err := cli.WriteStreamResumable(context.Background(), "/empty.bin", bytes.NewReader(nil), 0, ResumeOptions{}); err != nil {
+		t.Fatalf("got: %v, want nil", err)
+	}
+
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	if backend.nputs != 1 {
+		t.Fatalf("got %d PUTs, want 1: the zero-length upload must still create the resource", backend.nputs)
+	}
+	if len(backend.data) != 0 {
+		t.Fatalf("got %d bytes, want 0", len(backend.data))
+	}
+}